@@ -0,0 +1,75 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+package boxcli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.jetpack.io/devbox/boxcli/featureflag"
+)
+
+// FeatureCmd registers the `devbox feature` command group. `list` shows each
+// flag's resolved value, which takes overrides in both
+// ~/.config/devbox/flags.json and <project>/.devbox/flags.json into account;
+// `enable`, `disable` and `reset` change only the user-level
+// ~/.config/devbox/flags.json.
+func FeatureCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "feature",
+		Short: "Manage devbox feature flags",
+	}
+	command.AddCommand(featureListCmd())
+	command.AddCommand(featureEnableCmd())
+	command.AddCommand(featureDisableCmd())
+	command.AddCommand(featureResetCmd())
+	return command
+}
+
+func featureListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List all feature flags",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for _, f := range featureflag.List() {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\tenabled=%v\tstage=%s\t%s\n",
+					f.Name(), f.Enabled(), f.Stage(), f.Description())
+			}
+			return nil
+		},
+	}
+}
+
+func featureEnableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "enable <name>",
+		Short: "Enable a feature flag for the current user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return featureflag.SetUserOverride(args[0], true)
+		},
+	}
+}
+
+func featureDisableCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "disable <name>",
+		Short: "Disable a feature flag for the current user",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return featureflag.SetUserOverride(args[0], false)
+		},
+	}
+}
+
+func featureResetCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reset <name>",
+		Short: "Remove a user-level override, falling back to the project override, environment, or default",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return featureflag.ResetUserOverride(args[0])
+		},
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+// Package featureflag lets devbox ship experimental capabilities (flakes,
+// remote build, mutagen sync) behind named flags that can be toggled via
+// environment variables or persisted overrides, without users having to
+// remember DEVBOX_FEATURE_* env vars.
+package featureflag
+
+import (
+	"os"
+	"strconv"
+	"sync"
+)
+
+// Stage communicates how stable a feature is expected to be.
+type Stage string
+
+const (
+	Alpha Stage = "alpha"
+	Beta  Stage = "beta"
+	GA    Stage = "ga"
+)
+
+// envPrefix is prepended to a feature's name to get its override env var,
+// e.g. feature "FLAKES" is controlled by DEVBOX_FEATURE_FLAKES.
+const envPrefix = "DEVBOX_FEATURE_"
+
+// Feature is a single named capability that can be enabled or disabled.
+type Feature struct {
+	name         string
+	defaultValue bool
+	description  string
+	stage        Stage
+}
+
+// Option configures a Feature at Register time.
+type Option func(*Feature)
+
+// Default sets whether the feature is enabled when nothing overrides it.
+func Default(enabled bool) Option {
+	return func(f *Feature) { f.defaultValue = enabled }
+}
+
+// Description documents what the feature does, e.g. for `devbox feature list`.
+func Description(d string) Option {
+	return func(f *Feature) { f.description = d }
+}
+
+// WithStage records how stable the feature is.
+func WithStage(s Stage) Option {
+	return func(f *Feature) { f.stage = s }
+}
+
+// Name returns the feature's registered name.
+func (f *Feature) Name() string { return f.name }
+
+// Description returns the feature's description, if any.
+func (f *Feature) Description() string { return f.description }
+
+// Stage returns the feature's stability stage.
+func (f *Feature) Stage() Stage { return f.stage }
+
+// EnvVar returns the environment variable that overrides this feature.
+func (f *Feature) EnvVar() string { return envPrefix + f.name }
+
+// Enabled reports whether the feature is on, resolving overrides in order:
+// project override, user override, environment variable, then the
+// feature's registered default.
+func (f *Feature) Enabled() bool {
+	if v, ok := currentProjectStore().lookup(f.name); ok {
+		return v
+	}
+	if v, ok := userStore().lookup(f.name); ok {
+		return v
+	}
+	if s, ok := os.LookupEnv(f.EnvVar()); ok {
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b
+		}
+	}
+	return f.defaultValue
+}
+
+var (
+	registryMu sync.Mutex
+	registry   = map[string]*Feature{}
+)
+
+// Register declares a feature flag by name. Calling Register again for the
+// same name replaces its definition, which is mainly useful for tests.
+func Register(name string, opts ...Option) *Feature {
+	f := &Feature{name: name}
+	for _, opt := range opts {
+		opt(f)
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry[name] = f
+	return f
+}
+
+// Get returns the named feature, registering it with a false default if
+// it hasn't been registered yet.
+func Get(name string) *Feature {
+	registryMu.Lock()
+	f, ok := registry[name]
+	registryMu.Unlock()
+	if ok {
+		return f
+	}
+	return Register(name)
+}
+
+// List returns every registered feature.
+func List() []*Feature {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	features := make([]*Feature, 0, len(registry))
+	for _, f := range registry {
+		features = append(features, f)
+	}
+	return features
+}
@@ -7,5 +7,9 @@ func Flakes() bool {
 }
 
 func init() {
-	disabled(FlakesEnv)
+	Register(FlakesEnv,
+		Default(false),
+		Description("Use Nix flakes instead of nix-env to build the environment"),
+		WithStage(Alpha),
+	)
 }
@@ -0,0 +1,135 @@
+package featureflag
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Store persists feature flag overrides as a flat name -> enabled map in a
+// JSON file.
+type Store struct {
+	path string
+
+	mu      sync.Mutex
+	loaded  bool
+	entries map[string]bool
+}
+
+func newStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// lookup returns the stored override for name, if any.
+func (s *Store) lookup(name string) (enabled bool, found bool) {
+	if s == nil {
+		return false, false
+	}
+	s.load()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	enabled, found = s.entries[name]
+	return enabled, found
+}
+
+// Set persists an override for name.
+func (s *Store) Set(name string, enabled bool) error {
+	s.load()
+
+	s.mu.Lock()
+	s.entries[name] = enabled
+	s.mu.Unlock()
+	return s.save()
+}
+
+// Reset removes any override for name, falling back to the next layer.
+func (s *Store) Reset(name string) error {
+	s.load()
+
+	s.mu.Lock()
+	delete(s.entries, name)
+	s.mu.Unlock()
+	return s.save()
+}
+
+func (s *Store) load() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	s.entries = map[string]bool{}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(data, &s.entries)
+}
+
+func (s *Store) save() error {
+	s.mu.Lock()
+	data, err := json.MarshalIndent(s.entries, "", "  ")
+	s.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0644)
+}
+
+var (
+	userStoreOnce sync.Once
+	userStoreVal  *Store
+
+	projectMu      sync.Mutex
+	projectStoreVal *Store
+)
+
+// userStore is the single store for ~/.config/devbox/flags.json.
+func userStore() *Store {
+	userStoreOnce.Do(func() {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			userStoreVal = newStore("")
+			return
+		}
+		userStoreVal = newStore(filepath.Join(home, ".config", "devbox", "flags.json"))
+	})
+	return userStoreVal
+}
+
+// currentProjectStore is the store for the current project's
+// <project>/.devbox/flags.json, or nil if SetProjectDir hasn't been called.
+func currentProjectStore() *Store {
+	projectMu.Lock()
+	defer projectMu.Unlock()
+	return projectStoreVal
+}
+
+// SetProjectDir points the project-level feature flag store at
+// <dir>/.devbox/flags.json. devbox.Open calls this once it's found the
+// project's devbox.json.
+func SetProjectDir(dir string) {
+	projectMu.Lock()
+	defer projectMu.Unlock()
+	projectStoreVal = newStore(filepath.Join(dir, ".devbox", "flags.json"))
+}
+
+// SetUserOverride persists a user-level override for the named feature,
+// backing `devbox feature enable|disable`.
+func SetUserOverride(name string, enabled bool) error {
+	return userStore().Set(name, enabled)
+}
+
+// ResetUserOverride removes the user-level override for the named feature,
+// backing `devbox feature reset`.
+func ResetUserOverride(name string) error {
+	return userStore().Reset(name)
+}
@@ -0,0 +1,42 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+package boxcli
+
+import (
+	"github.com/spf13/cobra"
+	"go.jetpack.io/devbox"
+	"go.jetpack.io/devbox/packaging"
+)
+
+type packageCmdFlags struct {
+	format    string
+	outputDir string
+}
+
+// PackageCmd registers `devbox package`, which builds a native Linux
+// package (deb, rpm, apk, or archlinux) containing the runtime closure of
+// the devbox environment, as an alternative to `devbox build`'s Docker
+// image.
+func PackageCmd() *cobra.Command {
+	flags := &packageCmdFlags{}
+	command := &cobra.Command{
+		Use:   "package",
+		Short: "Build a native package (deb, rpm, apk, archlinux) from this devbox environment",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			box, err := devbox.Open(".", cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			return box.Package(
+				flags.format,
+				packaging.WithOutputDir(flags.outputDir),
+			)
+		},
+	}
+
+	command.Flags().StringVar(&flags.format, "format", "deb", "package format to build: deb, rpm, apk, or archlinux")
+	command.Flags().StringVar(&flags.outputDir, "output-dir", ".", "directory to write the built package to")
+	return command
+}
@@ -0,0 +1,105 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+package boxcli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"go.jetpack.io/devbox"
+)
+
+// SyncCmd registers the `devbox sync` command group, which manages the
+// mutagen file sync session configured by a project's `sync:` block.
+func SyncCmd() *cobra.Command {
+	command := &cobra.Command{
+		Use:   "sync",
+		Short: "Manage the devbox file sync session",
+	}
+	command.AddCommand(syncListCmd())
+	command.AddCommand(syncPauseCmd())
+	command.AddCommand(syncResumeCmd())
+	command.AddCommand(syncFlushCmd())
+	command.AddCommand(syncTerminateCmd())
+	return command
+}
+
+func syncListCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List sync sessions",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			box, err := devbox.Open(".", cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			client, err := box.SyncClient()
+			if err != nil {
+				return err
+			}
+			sessions, err := client.List()
+			if err != nil {
+				return err
+			}
+			for _, s := range sessions {
+				fmt.Fprintf(cmd.OutOrStdout(), "%s\tpaused=%v\n", s.Name, s.Paused)
+			}
+			return nil
+		},
+	}
+}
+
+func syncPauseCmd() *cobra.Command {
+	return syncActionCmd("pause", "Pause the sync session", func(c *devbox.Devbox) error {
+		client, err := c.SyncClient()
+		if err != nil {
+			return err
+		}
+		return client.Pause(c.SyncSessionName())
+	})
+}
+
+func syncResumeCmd() *cobra.Command {
+	return syncActionCmd("resume", "Resume the sync session", func(c *devbox.Devbox) error {
+		client, err := c.SyncClient()
+		if err != nil {
+			return err
+		}
+		return client.Resume(c.SyncSessionName())
+	})
+}
+
+func syncFlushCmd() *cobra.Command {
+	return syncActionCmd("flush", "Force the sync session to synchronize now", func(c *devbox.Devbox) error {
+		client, err := c.SyncClient()
+		if err != nil {
+			return err
+		}
+		return client.Flush(c.SyncSessionName())
+	})
+}
+
+func syncTerminateCmd() *cobra.Command {
+	return syncActionCmd("terminate", "Permanently end the sync session", func(c *devbox.Devbox) error {
+		client, err := c.SyncClient()
+		if err != nil {
+			return err
+		}
+		return client.Terminate(c.SyncSessionName())
+	})
+}
+
+func syncActionCmd(use, short string, run func(*devbox.Devbox) error) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			box, err := devbox.Open(".", cmd.OutOrStdout())
+			if err != nil {
+				return err
+			}
+			return run(box)
+		},
+	}
+}
@@ -0,0 +1,106 @@
+package mutagen
+
+import (
+	"bytes"
+	"encoding/json"
+	"os/exec"
+
+	"github.com/pkg/errors"
+)
+
+// Client shells out to an installed mutagen binary to manage sync sessions.
+type Client struct {
+	binPath string
+}
+
+// NewClient returns a Client that drives the mutagen binary at binPath.
+func NewClient(binPath string) *Client {
+	return &Client{binPath: binPath}
+}
+
+// CreateOrResume starts a new sync session for spec, or resumes the existing
+// session named spec.Name if mutagen already has one, paused or not.
+func (c *Client) CreateOrResume(spec *SessionSpec) error {
+	if err := spec.Validate(); err != nil {
+		return err
+	}
+
+	sessions, err := c.List()
+	if err != nil {
+		return err
+	}
+	for _, s := range sessions {
+		if s.Name == spec.Name {
+			return c.Resume(spec.Name)
+		}
+	}
+
+	args := []string{"sync", "create", spec.AlphaPath, spec.BetaAddress + ":" + spec.BetaPath}
+	if spec.Name != "" {
+		args = append(args, "--name", spec.Name)
+	}
+	if spec.SyncMode != "" {
+		args = append(args, "--sync-mode", spec.SyncMode)
+	}
+	if spec.IgnoreVCS {
+		args = append(args, "--ignore-vcs")
+	}
+	for k, v := range spec.Labels {
+		args = append(args, "--label", k+"="+v)
+	}
+	_, err = c.run(args...)
+	return err
+}
+
+// List returns every sync session mutagen knows about.
+func (c *Client) List() ([]Session, error) {
+	out, err := c.run("sync", "list", "--template", "{{json .}}")
+	if err != nil {
+		return nil, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return nil, nil
+	}
+
+	var sessions []Session
+	if err := json.Unmarshal(out, &sessions); err != nil {
+		return nil, errors.Wrap(err, "parse mutagen sync list output")
+	}
+	return sessions, nil
+}
+
+// Pause pauses the sync session with the given name.
+func (c *Client) Pause(name string) error {
+	_, err := c.run("sync", "pause", name)
+	return err
+}
+
+// Resume resumes the sync session with the given name.
+func (c *Client) Resume(name string) error {
+	_, err := c.run("sync", "resume", name)
+	return err
+}
+
+// Flush forces the sync session with the given name to synchronize now.
+func (c *Client) Flush(name string) error {
+	_, err := c.run("sync", "flush", name)
+	return err
+}
+
+// Terminate permanently ends the sync session with the given name.
+func (c *Client) Terminate(name string) error {
+	_, err := c.run("sync", "terminate", name)
+	return err
+}
+
+func (c *Client) run(args ...string) ([]byte, error) {
+	cmd := exec.Command(c.binPath, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Errorf("mutagen %v: %v: %s", args, err, stderr.String())
+	}
+	return stdout.Bytes(), nil
+}
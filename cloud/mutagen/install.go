@@ -1,60 +1,212 @@
 package mutagen
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/cavaliergopher/grab/v3"
+	"github.com/jedisct1/go-minisign"
+	"github.com/pkg/errors"
 )
 
-func InstallMutagenOnce(binPath string) error {
+// releaseRepo and releasePkg identify the GitHub repo and asset name
+// mutagen releases under.
+const (
+	releaseRepo = "mutagen-io/mutagen"
+	releasePkg  = "mutagen"
+)
+
+// ErrChecksumMismatch is returned by Install when a downloaded mutagen
+// tarball's SHA-256 digest doesn't match the digest published in the
+// release's own checksums manifest.
+var ErrChecksumMismatch = errors.New("mutagen: checksum mismatch")
+
+// ErrSignatureInvalid is returned by Install when a downloaded mutagen
+// checksums manifest's signature doesn't verify against its pinned public
+// key.
+var ErrSignatureInvalid = errors.New("mutagen: signature invalid")
+
+type installOptions struct {
+	version string
+}
+
+// InstallOption configures Install and InstallMutagenOnce.
+type InstallOption func(*installOptions)
+
+// WithVersion selects the mutagen release to install. v must be a version
+// present in ReleaseCatalog. Defaults to defaultVersion.
+func WithVersion(v string) InstallOption {
+	return func(o *installOptions) { o.version = v }
+}
+
+func InstallMutagenOnce(binPath string, opts ...InstallOption) error {
 	if IsFile(binPath) {
-		// Already installed, do nothing
-		// TODO: ideally we would check that the right version
-		//   is installed, and maybe we should also validate
-		//   with a checksum.
+		// Already installed, do nothing.
+		// TODO: verify the installed binary is the version we expect.
 		return nil
 	}
 
-	url := mutagenURL()
 	installDir := filepath.Dir(binPath)
-
-	return Install(url, installDir)
+	return Install(installDir, opts...)
 }
 
-func Install(url string, installDir string) error {
-	err := os.MkdirAll(installDir, 0755)
-	if err != nil {
+// Install downloads the pinned mutagen release into installDir. The
+// tarball's SHA-256 digest is validated as it streams to disk against the
+// digest published in the release's own checksums manifest (rather than a
+// hand-maintained digest here, which would go stale the moment a new
+// platform tarball is published); if the release has a public key pinned,
+// that manifest's signature is verified against it before the digest is
+// trusted.
+func Install(installDir string, opts ...InstallOption) error {
+	o := &installOptions{version: defaultVersion}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	release, ok := ReleaseCatalog[o.version]
+	if !ok {
+		return errors.Errorf("mutagen: no pinned release for version %q", o.version)
+	}
+
+	if err := os.MkdirAll(installDir, 0755); err != nil {
 		return err
 	}
 
-	// TODO: add checksum validation
-	resp, err := grab.Get(os.TempDir(), url)
+	tarURL := mutagenURL(release.Version)
+	sum, err := fetchChecksum(release, filepath.Base(tarURL))
 	if err != nil {
 		return err
 	}
 
-	tarPath := resp.Filename
-	tarReader, err := os.Open(tarPath)
+	req, err := grab.NewRequest(os.TempDir(), tarURL)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	req.SetChecksum(sha256.New(), sum, true)
+
+	resp := grab.DefaultClient.Do(req)
+	if err := resp.Err(); err != nil {
+		if errors.Is(err, grab.ErrBadChecksum) {
+			return errors.Wrap(ErrChecksumMismatch, err.Error())
+		}
+		return errors.WithStack(err)
+	}
+
+	tarReader, err := os.Open(resp.Filename)
 	if err != nil {
 		return err
 	}
-	err = Untar(tarReader, installDir)
+	defer tarReader.Close()
+
+	return Untar(tarReader, installDir)
+}
+
+// fetchChecksum downloads the release's checksums manifest, verifies its
+// signature against release.PublicKey (when pinned), and returns the
+// SHA-256 digest it lists for filename.
+func fetchChecksum(release MutagenRelease, filename string) ([]byte, error) {
+	manifestURL := checksumsURL(release.Version)
+
+	resp, err := grab.Get(os.TempDir(), manifestURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "download mutagen release checksums")
+	}
+
+	if release.PublicKey != "" {
+		sigPath, err := fetchSignature(manifestURL)
+		if err != nil {
+			return nil, err
+		}
+		if err := verifySignature(resp.Filename, sigPath, release.PublicKey); err != nil {
+			return nil, err
+		}
+	}
+
+	manifest, err := os.ReadFile(resp.Filename)
+	if err != nil {
+		return nil, err
+	}
+
+	sumHex, err := findChecksum(string(manifest), filename)
+	if err != nil {
+		return nil, err
+	}
+
+	sum, err := hex.DecodeString(sumHex)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decode checksum for %s", filename)
+	}
+	return sum, nil
+}
+
+// findChecksum scans a "<sha256>  <filename>" style checksums manifest for
+// the digest belonging to filename.
+func findChecksum(manifest, filename string) (string, error) {
+	for _, line := range strings.Split(manifest, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[1] == filename {
+			return fields[0], nil
+		}
+	}
+	return "", errors.Errorf("no checksum found for %s in mutagen release manifest", filename)
+}
+
+// fetchSignature downloads the detached minisign signature published
+// alongside a mutagen release file at url (conventionally url+".minisig")
+// and returns the local path it was saved to.
+func fetchSignature(url string) (string, error) {
+	resp, err := grab.Get(os.TempDir(), url+".minisig")
+	if err != nil {
+		return "", errors.Wrap(err, "download mutagen release signature")
+	}
+	return resp.Filename, nil
+}
+
+// verifySignature checks the file at dataPath against the detached minisign
+// signature file at sigPath using the given base64-encoded minisign public
+// key.
+func verifySignature(dataPath, sigPath, publicKey string) error {
+	pub, err := minisign.NewPublicKey(publicKey)
+	if err != nil {
+		return errors.Wrap(err, "parse mutagen release public key")
+	}
+
+	sigBytes, err := os.ReadFile(sigPath)
+	if err != nil {
+		return errors.Wrap(err, "read mutagen release signature")
+	}
+	sig, err := minisign.DecodeSignature(string(sigBytes))
+	if err != nil {
+		return errors.Wrap(err, "decode mutagen release signature")
+	}
+
+	data, err := os.ReadFile(dataPath)
 	if err != nil {
 		return err
 	}
+
+	ok, err := pub.Verify(data, sig)
+	if err != nil || !ok {
+		return ErrSignatureInvalid
+	}
 	return nil
 }
 
-func mutagenURL() string {
-	repo := "mutagen-io/mutagen"
-	pkg := "mutagen"
-	version := "v0.16.1" // Hard-coded for now, but change to always get the latest?
+// mutagenURL returns the download URL for the release tarball matching the
+// running platform.
+func mutagenURL(version string) string {
 	platform := detectPlatform()
+	return fmt.Sprintf("https://github.com/%s/releases/download/%s/%s_%s_%s.tar.gz", releaseRepo, version, releasePkg, platform, version)
+}
 
-	return fmt.Sprintf("https://github.com/%s/releases/download/%s/%s_%s_%s.tar.gz", repo, version, pkg, platform, version)
+// checksumsURL returns the download URL for a release's checksums manifest.
+func checksumsURL(version string) string {
+	return fmt.Sprintf("https://github.com/%s/releases/download/%s/%s_%s_checksums.txt", releaseRepo, version, releasePkg, version)
 }
 
 func detectOS() string {
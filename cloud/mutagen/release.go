@@ -0,0 +1,44 @@
+package mutagen
+
+import (
+	_ "embed"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// MutagenRelease pins a single published mutagen release: the version we
+// download and a minisign public key to verify it against. The SHA-256
+// digest of each platform's tarball isn't hand-maintained here — it's read
+// at install time from the release's own signed checksums manifest, so a
+// stale or mistyped digest can't silently break installs.
+type MutagenRelease struct {
+	Version   string `json:"version"`
+	PublicKey string `json:"public_key,omitempty"`
+}
+
+//go:embed releases.json
+var releasesJSON []byte
+
+// ReleaseCatalog maps a mutagen version string (e.g. "v0.16.1") to the
+// pinned release metadata devbox trusts for it. It's populated once from the
+// embedded releases.json, so adding support for a new mutagen version is a
+// data change, not a code change.
+var ReleaseCatalog = mustParseCatalog(releasesJSON)
+
+// defaultVersion is the mutagen version installed when callers don't
+// request a specific one via WithVersion.
+const defaultVersion = "v0.16.1"
+
+func mustParseCatalog(data []byte) map[string]MutagenRelease {
+	var releases []MutagenRelease
+	if err := json.Unmarshal(data, &releases); err != nil {
+		panic(errors.Wrap(err, "parse embedded mutagen release catalog"))
+	}
+
+	catalog := make(map[string]MutagenRelease, len(releases))
+	for _, r := range releases {
+		catalog[r.Version] = r
+	}
+	return catalog
+}
@@ -0,0 +1,91 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+package devbox
+
+import (
+	"strings"
+
+	"go.jetpack.io/devbox/cuecfg"
+)
+
+// Stage describes a single step (install, build, or start) that devbox runs
+// while building or running a project's environment.
+type Stage struct {
+	Command string `json:"command,omitempty"`
+}
+
+// ShellConfig configures the behavior of `devbox shell`.
+type ShellConfig struct {
+	InitHook InitHook `json:"init_hook,omitempty"`
+}
+
+// InitHook is a shell script, expressed as one command per line, that runs
+// whenever a devbox shell starts.
+type InitHook []string
+
+func (h InitHook) String() string {
+	return strings.Join(h, "\n")
+}
+
+// Config defines a devbox environment as read from devbox.json.
+type Config struct {
+	// Packages is the list of Nix packages that are part of this environment.
+	Packages []string `json:"packages,omitempty"`
+	// Shell configures the interactive shell started by `devbox shell`.
+	Shell ShellConfig `json:"shell,omitempty"`
+	// InstallStage runs after packages are installed but before BuildStage.
+	InstallStage *Stage `json:"install_stage,omitempty"`
+	// BuildStage runs to produce build artifacts for the environment.
+	BuildStage *Stage `json:"build_stage,omitempty"`
+	// StartStage runs as the entrypoint of a built environment.
+	StartStage *Stage `json:"start_stage,omitempty"`
+
+	// Maintainer is the person or team responsible for this environment.
+	// It's used to populate metadata when packaging the environment, e.g.
+	// via `devbox package`.
+	Maintainer string `json:"maintainer,omitempty"`
+	// Version is the version number attached to packages built from this
+	// environment.
+	Version string `json:"version,omitempty"`
+	// License is the SPDX license identifier attached to packages built
+	// from this environment.
+	License string `json:"license,omitempty"`
+	// Deps lists the native package dependencies (e.g. deb/rpm package
+	// names) that built packages should declare, in addition to whatever
+	// devbox resolves from Packages.
+	Deps []string `json:"deps,omitempty"`
+
+	// Sync configures a two-way mutagen file sync session that devbox
+	// starts on `devbox shell` entry and pauses on exit. It's most useful
+	// for syncing a local project directory into a remote devbox running
+	// on a build server or container.
+	Sync *SyncConfig `json:"sync,omitempty"`
+}
+
+// SyncConfig configures a mutagen sync session between this project
+// directory (alpha) and a remote endpoint (beta).
+type SyncConfig struct {
+	// Alpha is the sync endpoint address for this project directory.
+	// Leave empty to default to the project directory itself.
+	Alpha string `json:"alpha,omitempty"`
+	// Beta is the remote endpoint address, e.g. user@host:/path.
+	Beta string `json:"beta"`
+	// Mode is the mutagen synchronization mode, e.g. "two-way-resolved".
+	Mode string `json:"mode,omitempty"`
+	// IgnoreVCS excludes version control metadata (e.g. .git) from sync.
+	IgnoreVCS bool `json:"ignore_vcs,omitempty"`
+	// Labels are attached to the mutagen session so it can be identified
+	// with `mutagen sync list -l`.
+	Labels map[string]string `json:"labels,omitempty"`
+}
+
+// ReadConfig reads a devbox.json file at path and unmarshals it into a
+// Config.
+func ReadConfig(path string) (*Config, error) {
+	cfg := &Config{}
+	if err := cuecfg.ParseFile(path, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
@@ -14,11 +14,19 @@ import (
 	"strings"
 
 	"github.com/pkg/errors"
+	"go.jetpack.io/devbox/boxcli/featureflag"
 	"go.jetpack.io/devbox/boxcli/usererr"
+	"go.jetpack.io/devbox/cloud/mutagen"
 	"go.jetpack.io/devbox/cuecfg"
 	"go.jetpack.io/devbox/debug"
+	"go.jetpack.io/devbox/distro"
+	_ "go.jetpack.io/devbox/distro/arch"
+	_ "go.jetpack.io/devbox/distro/centos"
+	_ "go.jetpack.io/devbox/distro/debian"
+	_ "go.jetpack.io/devbox/distro/ubuntu"
 	"go.jetpack.io/devbox/docker"
 	"go.jetpack.io/devbox/nix"
+	"go.jetpack.io/devbox/packaging"
 	"go.jetpack.io/devbox/pkgslice"
 	"go.jetpack.io/devbox/planner"
 	"go.jetpack.io/devbox/planner/plansdk"
@@ -36,6 +44,10 @@ const (
 
 	// shellHistoryFile keeps the history of commands invoked inside devbox shell
 	shellHistoryFile = ".devbox/shell_history"
+
+	// mutagenBinPath is where devbox installs the mutagen binary it uses
+	// to drive file sync sessions.
+	mutagenBinPath = ".devbox/bin/mutagen"
 )
 
 // InitConfig creates a default devbox config file if one doesn't already
@@ -68,6 +80,8 @@ func Open(dir string, writer io.Writer) (*Devbox, error) {
 		return nil, errors.WithStack(err)
 	}
 
+	featureflag.SetProjectDir(cfgDir)
+
 	box := &Devbox{
 		cfg:    cfg,
 		srcDir: cfgDir,
@@ -120,8 +134,41 @@ func (d *Devbox) Remove(pkgs ...string) error {
 	return d.printPackageUpdateMessage(uninstall, pkgs)
 }
 
+// Bootstrap prepares a bare Linux host (e.g. a CI image that doesn't already
+// have Nix) to run devbox, by installing the Nix prerequisites (curl, xz)
+// via the host's native package manager, plus the distro's build tools
+// package and kernel headers when devbox.json has a build stage. It does
+// nothing when Nix is already installed.
+func (d *Devbox) Bootstrap() error {
+	if nix.Installed() {
+		return nil
+	}
+
+	dist, err := distro.Detect()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	for _, pkg := range dist.Prerequisites() {
+		if err := dist.Install(pkg, false); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+
+	if d.cfg.BuildStage != nil {
+		if err := dist.Install(dist.BuildToolsPackage(), true /* headers */); err != nil {
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
 // Build creates a Docker image containing a shell with the devbox environment.
 func (d *Devbox) Build(flags *docker.BuildFlags) error {
+	if err := d.Bootstrap(); err != nil {
+		return errors.WithStack(err)
+	}
+
 	defaultFlags := &docker.BuildFlags{
 		Name:           flags.Name,
 		DockerfilePath: filepath.Join(d.srcDir, ".devbox/gen", "Dockerfile"),
@@ -135,6 +182,42 @@ func (d *Devbox) Build(flags *docker.BuildFlags) error {
 	return docker.Build(d.srcDir, opts...)
 }
 
+// Package builds a native Linux package (deb, rpm, apk, or archlinux) that
+// contains the runtime closure of the devbox environment, as an alternative
+// to the Docker image produced by Build.
+func (d *Devbox) Package(format string, opts ...packaging.PackageOption) error {
+	plan, err := d.BuildPlan()
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if plan.Invalid() {
+		return plan.Error()
+	}
+
+	if err := d.ensurePackagesAreInstalled(install); err != nil {
+		return err
+	}
+
+	profileDir, err := d.profileDir()
+	if err != nil {
+		return err
+	}
+	profilePaths, err := nix.StoreClosure(profileDir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	meta := packaging.Metadata{
+		Name:       filepath.Base(d.srcDir),
+		Version:    d.cfg.Version,
+		Maintainer: d.cfg.Maintainer,
+		License:    d.cfg.License,
+		Deps:       d.cfg.Deps,
+	}
+
+	return packaging.Package(format, plan, meta, profilePaths, opts...)
+}
+
 // Plan creates a plan of the actions that devbox will take to generate its
 // shell environment.
 func (d *Devbox) ShellPlan() *plansdk.Plan {
@@ -172,6 +255,13 @@ func (d *Devbox) Shell() error {
 		return err
 	}
 
+	if d.cfg.Sync != nil {
+		if err := d.startSync(); err != nil {
+			return err
+		}
+		defer d.pauseSync()
+	}
+
 	profileDir, err := d.profileDir()
 	if err != nil {
 		return err
@@ -190,6 +280,75 @@ func (d *Devbox) Shell() error {
 	return sh.Run(nixShellFilePath)
 }
 
+// SyncClient returns a mutagen.Client for this project, installing the
+// mutagen binary on first use.
+func (d *Devbox) SyncClient() (*mutagen.Client, error) {
+	binPath := filepath.Join(d.srcDir, mutagenBinPath)
+	if err := mutagen.InstallMutagenOnce(binPath); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return mutagen.NewClient(binPath), nil
+}
+
+// SyncSessionName returns the mutagen session name devbox uses for this
+// project's sync: config block.
+func (d *Devbox) SyncSessionName() string {
+	return "devbox-" + filepath.Base(d.srcDir)
+}
+
+// syncSessionSpec builds the mutagen session spec for this project's
+// sync: config block. It's only valid to call when d.cfg.Sync is set.
+func (d *Devbox) syncSessionSpec() *mutagen.SessionSpec {
+	sync := d.cfg.Sync
+	alpha := sync.Alpha
+	if alpha == "" {
+		alpha = d.srcDir
+	}
+	betaAddress, betaPath := splitEndpoint(sync.Beta)
+	return &mutagen.SessionSpec{
+		Name:        d.SyncSessionName(),
+		AlphaPath:   alpha,
+		BetaAddress: betaAddress,
+		BetaPath:    betaPath,
+		SyncMode:    sync.Mode,
+		IgnoreVCS:   sync.IgnoreVCS,
+		Labels:      sync.Labels,
+	}
+}
+
+// splitEndpoint splits a mutagen-style remote endpoint, e.g.
+// "user@host:/path", into its address and path parts.
+func splitEndpoint(endpoint string) (address, path string) {
+	address, path, found := strings.Cut(endpoint, ":")
+	if !found {
+		return "", endpoint
+	}
+	return address, path
+}
+
+// startSync creates (or resumes) the mutagen sync session described by this
+// project's sync: config block.
+func (d *Devbox) startSync() error {
+	client, err := d.SyncClient()
+	if err != nil {
+		return err
+	}
+	return client.CreateOrResume(d.syncSessionSpec())
+}
+
+// pauseSync pauses the mutagen sync session for this project. Errors are
+// logged rather than returned since this runs on shell exit.
+func (d *Devbox) pauseSync() {
+	client, err := d.SyncClient()
+	if err != nil {
+		fmt.Println("ERROR: pausing sync session:", err)
+		return
+	}
+	if err := client.Pause(d.SyncSessionName()); err != nil {
+		fmt.Println("ERROR: pausing sync session:", err)
+	}
+}
+
 func (d *Devbox) Exec(cmds ...string) error {
 	if err := d.ensurePackagesAreInstalled(install); err != nil {
 		return err
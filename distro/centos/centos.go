@@ -0,0 +1,62 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+// Package centos implements distro.Distro for CentOS.
+package centos
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.jetpack.io/devbox/distro"
+)
+
+func init() {
+	distro.Register("centos", &Distro{})
+}
+
+// Distro installs packages on CentOS via yum.
+type Distro struct{}
+
+func (d *Distro) Name() string { return "centos" }
+
+func (d *Distro) Prerequisites() []string { return []string{"curl", "xz"} }
+
+func (d *Distro) BuildToolsPackage() string { return "gcc" }
+
+func (d *Distro) Install(pkg string, headers bool) error {
+	pkgs := []string{pkg}
+	if headers {
+		pkgs = append(pkgs, "kernel-devel", "kernel-headers")
+	}
+
+	cmd := exec.Command("yum", append([]string{"install", "-y"}, pkgs...)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "yum install %v", pkgs)
+	}
+	return nil
+}
+
+func (d *Distro) Kernels() ([]distro.KernelInfo, error) {
+	release, err := kernelRelease()
+	if err != nil {
+		return nil, err
+	}
+	_, err = os.Stat("/usr/src/kernels/" + release)
+	return []distro.KernelInfo{{
+		Release:          release,
+		HeadersInstalled: err == nil,
+	}}, nil
+}
+
+func kernelRelease() (string, error) {
+	out, err := exec.Command("uname", "-r").Output()
+	if err != nil {
+		return "", errors.Wrap(err, "uname -r")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,66 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+// Package debian implements distro.Distro for Debian.
+package debian
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/pkg/errors"
+	"go.jetpack.io/devbox/distro"
+)
+
+func init() {
+	distro.Register("debian", &Distro{})
+}
+
+// Distro installs packages on Debian via apt-get.
+type Distro struct{}
+
+func (d *Distro) Name() string { return "debian" }
+
+func (d *Distro) Prerequisites() []string { return []string{"curl", "xz-utils"} }
+
+func (d *Distro) BuildToolsPackage() string { return "build-essential" }
+
+func (d *Distro) Install(pkg string, headers bool) error {
+	pkgs := []string{pkg}
+	if headers {
+		release, err := kernelRelease()
+		if err != nil {
+			return err
+		}
+		pkgs = append(pkgs, "linux-headers-"+release)
+	}
+
+	cmd := exec.Command("apt-get", append([]string{"install", "-y"}, pkgs...)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return errors.Wrapf(err, "apt-get install %v", pkgs)
+	}
+	return nil
+}
+
+func (d *Distro) Kernels() ([]distro.KernelInfo, error) {
+	release, err := kernelRelease()
+	if err != nil {
+		return nil, err
+	}
+	_, err = os.Stat("/usr/src/linux-headers-" + release)
+	return []distro.KernelInfo{{
+		Release:          release,
+		HeadersInstalled: err == nil,
+	}}, nil
+}
+
+func kernelRelease() (string, error) {
+	out, err := exec.Command("uname", "-r").Output()
+	if err != nil {
+		return "", errors.Wrap(err, "uname -r")
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,88 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+// Package distro abstracts over the Linux distribution devbox is running on,
+// so devbox can install the OS packages (curl, xz, kernel headers, ...) it
+// needs before the Nix layer runs.
+package distro
+
+import (
+	"bufio"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// KernelInfo describes a kernel that's installed, or could be installed, on
+// the host.
+type KernelInfo struct {
+	// Release is the kernel release string, e.g. "5.15.0-79-generic".
+	Release string
+	// HeadersInstalled reports whether this kernel's headers package is
+	// already installed.
+	HeadersInstalled bool
+}
+
+// Distro installs system packages on a particular Linux distribution.
+type Distro interface {
+	// Name identifies the distro, e.g. "debian" or "ubuntu".
+	Name() string
+	// Install installs pkg using the distro's native package manager. If
+	// headers is true, the headers package for the running kernel is
+	// installed as well, so Nix can build packages with kernel modules.
+	Install(pkg string, headers bool) error
+	// Kernels reports the running kernel (as given by `uname -r`) and
+	// whether its headers are installed; other installed kernels are not
+	// listed.
+	Kernels() ([]KernelInfo, error)
+	// Prerequisites returns this distro's package names for the tools Nix
+	// itself needs to install and run: a downloader and an archive tool.
+	Prerequisites() []string
+	// BuildToolsPackage returns this distro's package name for a C
+	// toolchain, needed when a project's BuildStage compiles native code.
+	BuildToolsPackage() string
+}
+
+var registry = map[string]Distro{}
+
+// Register adds a Distro implementation under the /etc/os-release ID it
+// handles (e.g. "debian", "ubuntu", "centos", "arch"). Called from the
+// init() of each distro/<name> package.
+func Register(id string, d Distro) {
+	registry[id] = d
+}
+
+// Detect identifies the host's Linux distribution by reading /etc/os-release
+// and returns the matching Distro.
+func Detect() (Distro, error) {
+	id, err := osReleaseID("/etc/os-release")
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	d, ok := registry[id]
+	if !ok {
+		return nil, errors.Errorf("unsupported distro %q", id)
+	}
+	return d, nil
+}
+
+// osReleaseID parses the ID field out of an /etc/os-release file.
+func osReleaseID(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "ID=") {
+			continue
+		}
+		id := strings.TrimPrefix(line, "ID=")
+		return strings.Trim(id, `"`), nil
+	}
+	return "", errors.New("no ID field found in os-release")
+}
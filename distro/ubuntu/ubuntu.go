@@ -0,0 +1,23 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+// Package ubuntu implements distro.Distro for Ubuntu, which shares Debian's
+// apt-get based package manager.
+package ubuntu
+
+import (
+	"go.jetpack.io/devbox/distro"
+	"go.jetpack.io/devbox/distro/debian"
+)
+
+func init() {
+	distro.Register("ubuntu", &Distro{})
+}
+
+// Distro installs packages on Ubuntu via apt-get, reusing Debian's
+// implementation since the package manager and headers naming match.
+type Distro struct {
+	debian.Distro
+}
+
+func (d *Distro) Name() string { return "ubuntu" }
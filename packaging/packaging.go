@@ -0,0 +1,169 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+// Package packaging builds native Linux packages (deb, rpm, apk, archlinux)
+// out of a devbox environment, as an alternative to the Docker image
+// produced by Devbox.Build.
+package packaging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/goreleaser/nfpm/v2"
+	_ "github.com/goreleaser/nfpm/v2/apk"
+	_ "github.com/goreleaser/nfpm/v2/arch"
+	_ "github.com/goreleaser/nfpm/v2/deb"
+	"github.com/goreleaser/nfpm/v2/files"
+	_ "github.com/goreleaser/nfpm/v2/rpm"
+	"github.com/pkg/errors"
+
+	"go.jetpack.io/devbox/planner/plansdk"
+)
+
+// Metadata holds the package-level information that ends up in the built
+// package's control/spec file.
+type Metadata struct {
+	Name       string
+	Version    string
+	Maintainer string
+	License    string
+	Deps       []string
+}
+
+// options collects the settings that PackageOption funcs mutate.
+type options struct {
+	outputDir string
+	arch      string
+}
+
+// PackageOption configures a call to Package.
+type PackageOption func(*options)
+
+// WithOutputDir sets the directory that the built package is written to.
+// Defaults to the current working directory.
+func WithOutputDir(dir string) PackageOption {
+	return func(o *options) { o.outputDir = dir }
+}
+
+// WithArch overrides the target architecture recorded in the package.
+// Defaults to runtime.GOARCH.
+func WithArch(arch string) PackageOption {
+	return func(o *options) { o.arch = arch }
+}
+
+// Package builds a native package in the given format (deb, rpm, apk, or
+// archlinux) that installs the runtime closure described by plan, along with
+// a start-stage entrypoint script and the metadata supplied in meta.
+// profilePaths is the set of Nix store paths that make up the resolved
+// profile; each is added to the package's file list so the runtime closure
+// is self-contained.
+func Package(format string, plan *plansdk.Plan, meta Metadata, profilePaths []string, opts ...PackageOption) error {
+	o := &options{outputDir: ".", arch: runtime.GOARCH}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	contents, err := closureContents(profilePaths)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	info := &nfpm.Info{
+		Name:        meta.Name,
+		Version:     meta.Version,
+		Maintainer:  meta.Maintainer,
+		License:     meta.License,
+		Arch:        o.arch,
+		Description: fmt.Sprintf("%s, packaged by devbox", meta.Name),
+		Overridables: nfpm.Overridables{
+			Depends:  meta.Deps,
+			Contents: contents,
+		},
+	}
+
+	if plan != nil && plan.StartStage != nil && plan.StartStage.Command != "" {
+		entrypoint, err := writeEntrypoint(o.outputDir, plan.StartStage.Command)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		info.Overridables.Contents = append(info.Overridables.Contents, &files.Content{
+			Source:      entrypoint,
+			Destination: "/usr/bin/" + meta.Name,
+			FileInfo:    &files.ContentFileInfo{Mode: 0755},
+		})
+	}
+
+	packager, err := nfpm.Get(format)
+	if err != nil {
+		return errors.Wrapf(err, "package format %q is not supported", format)
+	}
+
+	outPath := filepath.Join(o.outputDir, packager.ConventionalFileName(info))
+	f, err := os.Create(outPath)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	defer f.Close()
+
+	if err := packager.Package(nfpm.WithDefaults(info), f); err != nil {
+		return errors.Wrapf(err, "package %s", format)
+	}
+	return nil
+}
+
+// closureContents walks the nix store paths that make up a built profile and
+// turns every file and symlink found under them into an nfpm file entry, so
+// the runtime closure is included in the package verbatim. Nix store paths
+// are almost always directories, so this has to recurse rather than add one
+// entry per top-level path.
+func closureContents(profilePaths []string) (files.Contents, error) {
+	var contents files.Contents
+	for _, storePath := range profilePaths {
+		storeDir := filepath.Dir(storePath)
+		err := filepath.Walk(storePath, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				return nil
+			}
+
+			rel, err := filepath.Rel(storeDir, path)
+			if err != nil {
+				return err
+			}
+			content := &files.Content{
+				Source:      path,
+				Destination: filepath.Join("/nix/store", rel),
+			}
+			if info.Mode()&os.ModeSymlink != 0 {
+				target, err := os.Readlink(path)
+				if err != nil {
+					return err
+				}
+				content.Type = "symlink"
+				content.Source = target
+			}
+			contents = append(contents, content)
+			return nil
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "walk nix store path %s", storePath)
+		}
+	}
+	return contents, nil
+}
+
+// writeEntrypoint renders a small shell script that execs the start-stage
+// command, so the built package has something to register as its binary.
+func writeEntrypoint(dir, cmd string) (string, error) {
+	path := filepath.Join(dir, "devbox-entrypoint.sh")
+	script := "#!/usr/bin/env sh\nexec " + cmd + "\n"
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		return "", err
+	}
+	return path, nil
+}
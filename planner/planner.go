@@ -0,0 +1,59 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+// Package planner inspects a project directory and infers a plansdk.Plan for
+// building and running it.
+package planner
+
+import (
+	"go.jetpack.io/devbox/planner/plansdk"
+)
+
+// builtins lists devbox's own planners, tried before anything registered or
+// discovered from plugins. Nothing adds to it, so it is empty and only
+// registered and plugin planners are consulted.
+var builtins []plansdk.Planner
+
+// GetBuildPlan infers a plansdk.Plan for the project in srcDir by asking,
+// in order, devbox's built-in planners, planners registered in-process via
+// plansdk.RegisterPlanner, and any plugin planners found under
+// ~/.devbox/plugins. Every planner that claims the project and returns a
+// valid plan contributes: the plans are merged in that order via
+// plansdk.MergeUserPlan, so an earlier planner's non-empty stages win
+// conflicts and the packages of all of them are combined. If no planner
+// applies, an empty Plan is returned.
+func GetBuildPlan(srcDir string) (*plansdk.Plan, error) {
+	plugins, err := plansdk.DiscoverPlugins()
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []plansdk.Planner
+	candidates = append(candidates, builtins...)
+	candidates = append(candidates, plansdk.RegisteredPlanners()...)
+	candidates = append(candidates, plugins...)
+
+	var plan *plansdk.Plan
+	for _, p := range candidates {
+		if !p.IsRelevant(srcDir) {
+			continue
+		}
+		found := p.GetPlan(srcDir)
+		if found.Invalid() {
+			continue
+		}
+		if plan == nil {
+			plan = found
+			continue
+		}
+		plan, err = plansdk.MergeUserPlan(plan, found)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if plan == nil {
+		return &plansdk.Plan{}, nil
+	}
+	return plan, nil
+}
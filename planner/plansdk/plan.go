@@ -0,0 +1,99 @@
+// Copyright 2022 Jetpack Technologies Inc and contributors. All rights reserved.
+// Use of this source code is governed by the license in the LICENSE file.
+
+// Package plansdk defines the Plan type that devbox uses to describe how to
+// build and run a project's environment, along with the SDK that lets
+// out-of-tree planners produce one.
+package plansdk
+
+import "os"
+
+// Stage describes a single step (install, build, or start) of a Plan.
+type Stage struct {
+	Command string `json:"command,omitempty"`
+}
+
+// Plan describes how devbox should build and run a project's environment:
+// which packages it needs and what to run at each stage.
+type Plan struct {
+	DevPackages     []string `json:"dev_packages,omitempty"`
+	RuntimePackages []string `json:"runtime_packages,omitempty"`
+	InstallStage    *Stage   `json:"install_stage,omitempty"`
+	BuildStage      *Stage   `json:"build_stage,omitempty"`
+	StartStage      *Stage   `json:"start_stage,omitempty"`
+
+	err     error
+	warning error
+}
+
+// WithError returns a Plan that's marked invalid, carrying err as the reason.
+func WithError(err error) *Plan {
+	return &Plan{err: err}
+}
+
+// WithWarning attaches warning to p and returns p; p itself is modified, not
+// copied. Unlike WithError, a warning doesn't make the plan invalid.
+func (p *Plan) WithWarning(warning error) *Plan {
+	p.warning = warning
+	return p
+}
+
+// Invalid reports whether the plan failed to generate and shouldn't be used.
+func (p *Plan) Invalid() bool {
+	return p.err != nil
+}
+
+// Error returns the reason the plan is invalid, or nil.
+func (p *Plan) Error() error {
+	return p.err
+}
+
+// Warning returns a non-fatal issue found while generating the plan, or nil.
+func (p *Plan) Warning() error {
+	return p.warning
+}
+
+// MergeUserPlan overlays the stages and packages a user hand-wrote in
+// devbox.json (userPlan) on top of the plan devbox inferred for the project
+// (buildPlan). User-specified fields always win.
+func MergeUserPlan(userPlan *Plan, buildPlan *Plan) (*Plan, error) {
+	if buildPlan.Invalid() {
+		return buildPlan, nil
+	}
+
+	merged := &Plan{
+		DevPackages:     mergePackages(userPlan.DevPackages, buildPlan.DevPackages),
+		RuntimePackages: mergePackages(userPlan.RuntimePackages, buildPlan.RuntimePackages),
+		InstallStage:    mergeStage(userPlan.InstallStage, buildPlan.InstallStage),
+		BuildStage:      mergeStage(userPlan.BuildStage, buildPlan.BuildStage),
+		StartStage:      mergeStage(userPlan.StartStage, buildPlan.StartStage),
+		warning:         buildPlan.warning,
+	}
+	return merged, nil
+}
+
+func mergePackages(user, build []string) []string {
+	seen := map[string]bool{}
+	merged := []string{}
+	for _, pkg := range append(append([]string{}, user...), build...) {
+		if seen[pkg] {
+			continue
+		}
+		seen[pkg] = true
+		merged = append(merged, pkg)
+	}
+	return merged
+}
+
+func mergeStage(user, build *Stage) *Stage {
+	if user != nil && user.Command != "" {
+		return user
+	}
+	return build
+}
+
+// FileExists reports whether path exists and is a regular file.
+func FileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
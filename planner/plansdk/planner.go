@@ -0,0 +1,47 @@
+package plansdk
+
+import "sync"
+
+// Planner inspects a project directory and, if it recognizes the project,
+// produces a Plan for it. Planners registered via RegisterPlanner and
+// out-of-tree plugin planners both implement this interface.
+type Planner interface {
+	// Name identifies the planner, e.g. "go" or a plugin's binary name.
+	Name() string
+	// IsRelevant reports whether this planner knows how to build srcDir.
+	IsRelevant(srcDir string) bool
+	// GetPlan produces a Plan for srcDir. Only called when IsRelevant
+	// returned true.
+	GetPlan(srcDir string) *Plan
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []namedPlanner
+)
+
+type namedPlanner struct {
+	name    string
+	planner Planner
+}
+
+// RegisterPlanner adds a third-party Planner to the set consulted by
+// planner.GetBuildPlan. Planners are tried in the order they were
+// registered, after devbox's built-in planners.
+func RegisterPlanner(name string, p Planner) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	registry = append(registry, namedPlanner{name: name, planner: p})
+}
+
+// RegisteredPlanners returns the third-party planners registered so far, in
+// registration order.
+func RegisteredPlanners() []Planner {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	planners := make([]Planner, len(registry))
+	for i, np := range registry {
+		planners[i] = np.planner
+	}
+	return planners
+}
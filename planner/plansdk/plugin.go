@@ -0,0 +1,169 @@
+package plansdk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// pluginDir is where devbox looks for third-party planner plugins.
+const pluginDir = ".devbox/plugins"
+
+// Request is the input a plugin planner receives on stdin, JSON-encoded.
+type Request struct {
+	// SrcDir is the absolute path to the project directory being planned.
+	SrcDir string `json:"src_dir"`
+	// Files lists the files devbox found directly under SrcDir, so a
+	// plugin can decide relevance without re-walking the directory.
+	Files []string `json:"files"`
+}
+
+// Response is the output a plugin planner writes to stdout, JSON-encoded.
+type Response struct {
+	// Relevant must be true for Plan to be used.
+	Relevant bool  `json:"relevant"`
+	Plan     *Plan `json:"plan,omitempty"`
+}
+
+// DiscoverPlugins scans ~/.devbox/plugins for executable files and wraps
+// each one as a Planner that speaks the JSON-over-stdio protocol defined by
+// Request/Response.
+func DiscoverPlugins() ([]Planner, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	dir := filepath.Join(home, pluginDir)
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	var planners []Planner
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil || info.Mode()&0111 == 0 {
+			continue
+		}
+		planners = append(planners, &pluginPlanner{
+			name: entry.Name(),
+			path: filepath.Join(dir, entry.Name()),
+		})
+	}
+	return planners, nil
+}
+
+// pluginPlanner adapts an external plugin binary to the Planner interface by
+// invoking it on the first call and caching the result for later calls,
+// since IsRelevant and GetPlan both need to run the same request.
+type pluginPlanner struct {
+	name string
+	path string
+
+	called   bool
+	response *Response
+	err      error
+}
+
+func (p *pluginPlanner) Name() string { return p.name }
+
+func (p *pluginPlanner) IsRelevant(srcDir string) bool {
+	resp, err := p.run(srcDir)
+	return err == nil && resp.Relevant
+}
+
+func (p *pluginPlanner) GetPlan(srcDir string) *Plan {
+	resp, err := p.run(srcDir)
+	if err != nil {
+		return WithError(err)
+	}
+	if resp.Plan == nil {
+		return WithError(errors.Errorf("planner plugin %s reported relevant but returned no plan", p.name))
+	}
+	return resp.Plan
+}
+
+func (p *pluginPlanner) run(srcDir string) (*Response, error) {
+	if p.called {
+		return p.response, p.err
+	}
+	p.called = true
+
+	entries, err := os.ReadDir(srcDir)
+	if err != nil {
+		p.err = errors.WithStack(err)
+		return nil, p.err
+	}
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		files = append(files, e.Name())
+	}
+
+	reqBytes, err := json.Marshal(Request{SrcDir: srcDir, Files: files})
+	if err != nil {
+		p.err = errors.WithStack(err)
+		return nil, p.err
+	}
+
+	cmd := exec.Command(p.path)
+	cmd.Stdin = bytes.NewReader(reqBytes)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		p.err = errors.Wrapf(err, "run planner plugin %s", p.name)
+		return nil, p.err
+	}
+
+	var resp Response
+	if err := json.Unmarshal(stdout.Bytes(), &resp); err != nil {
+		p.err = errors.Wrapf(err, "parse output of planner plugin %s", p.name)
+		return nil, p.err
+	}
+	if resp.Relevant && resp.Plan == nil {
+		p.err = errors.Errorf("planner plugin %s reported relevant but returned no plan", p.name)
+		return nil, p.err
+	}
+	p.response = &resp
+	return p.response, nil
+}
+
+// RunPlugin implements the plugin side of the JSON-over-stdio protocol. A
+// third-party planner binary should do nothing but call this from main:
+//
+//	func main() {
+//		plansdk.RunPlugin(func(req plansdk.Request) (plansdk.Plan, error) {
+//			...
+//		})
+//	}
+func RunPlugin(getPlan func(Request) (Plan, error)) {
+	var req Request
+	if err := json.NewDecoder(os.Stdin).Decode(&req); err != nil {
+		fmt.Fprintln(os.Stderr, "plansdk: decode request:", err)
+		os.Exit(1)
+	}
+
+	plan, err := getPlan(req)
+	resp := Response{Relevant: err == nil, Plan: &plan}
+	if err != nil {
+		resp.Relevant = false
+	}
+
+	if err := json.NewEncoder(os.Stdout).Encode(resp); err != nil {
+		fmt.Fprintln(os.Stderr, "plansdk: encode response:", err)
+		os.Exit(1)
+	}
+}